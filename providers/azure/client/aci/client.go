@@ -0,0 +1,296 @@
+// Package aci is a thin adapter over the official
+// "containerinstance" SDK package, exposing the container group
+// operations the virtual-kubelet ACI provider needs under the
+// package's own, pre-existing types (see type.go). Keeping our own
+// types means the rest of the provider doesn't need to change every
+// time the generated SDK does, and gives us a place to carry fields
+// (Extensions, InitContainers, Sku, ConfidentialComputeProperties)
+// that the generated SDK type hasn't picked up yet.
+package aci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+)
+
+// Client wraps the generated containerinstance.ContainerGroupsClient
+// with the subscription ID and authorizer resolved once at
+// construction time.
+type Client struct {
+	groupsClient containerinstance.ContainerGroupsClient
+}
+
+// NewClient creates a new aci.Client, authorizing against Azure
+// Resource Manager the same way resourcegroups.NewClient and
+// network.NewClient do: see resolveAuthorizer.
+func NewClient() (*Client, error) {
+	authorizer, subscriptionID, err := resolveAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	groupsClient := containerinstance.NewContainerGroupsClient(subscriptionID)
+	groupsClient.Authorizer = authorizer
+
+	return &Client{groupsClient: groupsClient}, nil
+}
+
+// resolveAuthorizer resolves an ARM authorizer and subscription ID,
+// preferring the credentials file pointed to by AZURE_AUTH_LOCATION
+// (service principal auth) and otherwise falling back to
+// auth.NewAuthorizerFromEnvironment (environment-variable credentials
+// or MSI) and then auth.NewAuthorizerFromCLI (an `az login` session),
+// so virtual-kubelet can run in-cluster or from a developer machine
+// without a credentials file. AZURE_SUBSCRIPTION_ID must be set for
+// either fallback, since neither one carries a subscription ID.
+func resolveAuthorizer() (autorest.Authorizer, string, error) {
+	if os.Getenv("AZURE_AUTH_LOCATION") != "" {
+		settings, err := auth.GetSettingsFromFile()
+		if err != nil {
+			return nil, "", err
+		}
+
+		authorizer, err := settings.ClientCredentialsAuthorizer(settings.Environment.ResourceManagerEndpoint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return authorizer, settings.GetSubscriptionID(), nil
+	}
+
+	subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+	if subscriptionID == "" {
+		return nil, "", errors.New("aci: set AZURE_AUTH_LOCATION, or AZURE_SUBSCRIPTION_ID plus environment/MSI/CLI credentials")
+	}
+
+	if authorizer, err := auth.NewAuthorizerFromEnvironment(); err == nil {
+		return authorizer, subscriptionID, nil
+	}
+
+	authorizer, err := auth.NewAuthorizerFromCLI()
+	if err != nil {
+		return nil, "", err
+	}
+	return authorizer, subscriptionID, nil
+}
+
+// CreateContainerGroup creates (or updates) a container group and
+// waits for the long-running create/update operation to finish,
+// returning the resulting container group.
+//
+// Fields the generated SDK type doesn't model yet (Extensions,
+// InitContainers, Sku, and ConfidentialComputeProperties) are sent
+// over a raw PUT instead of through the SDK client, since marshaling
+// through containerinstance.ContainerGroup would silently drop them.
+//
+// cg is validated with ValidateContainerGroup before anything is sent
+// to ARM, so invalid combinations (e.g. GPU on Windows) fail fast with
+// a clear, local error.
+func (c *Client) CreateContainerGroup(resourceGroup, containerGroupName string, cg ContainerGroup) (*ContainerGroup, error) {
+	if err := ValidateContainerGroup(cg); err != nil {
+		return nil, err
+	}
+
+	if needsRawTransport(cg) {
+		return c.createContainerGroupRaw(resourceGroup, containerGroupName, cg)
+	}
+
+	future, err := c.groupsClient.CreateOrUpdate(context.Background(), resourceGroup, containerGroupName, toSDKContainerGroup(cg))
+	if err != nil {
+		return nil, unwrapCloudError(err)
+	}
+
+	if err := future.WaitForCompletionRef(context.Background(), c.groupsClient.Client); err != nil {
+		return nil, unwrapCloudError(err)
+	}
+
+	result, err := future.Result(c.groupsClient)
+	if err != nil {
+		return nil, unwrapCloudError(err)
+	}
+
+	converted := fromSDKContainerGroup(result)
+	return &converted, nil
+}
+
+// GetContainerGroup fetches a single container group by name. The
+// third return value is the raw HTTP status code, preserved for
+// callers (e.g. provider pod status reporting) that need to
+// distinguish "not found" from other errors without parsing err.
+func (c *Client) GetContainerGroup(resourceGroup, containerGroupName string) (*ContainerGroup, error, int) {
+	result, err := c.groupsClient.Get(context.Background(), resourceGroup, containerGroupName)
+	statusCode := 0
+	if result.Response.Response != nil {
+		statusCode = result.Response.StatusCode
+	}
+	if err != nil {
+		return nil, unwrapCloudError(err), statusCode
+	}
+
+	converted := fromSDKContainerGroup(result)
+	return &converted, nil, statusCode
+}
+
+// ListContainerGroups lists the container groups in a resource group,
+// paging through all results via the SDK's pager.
+func (c *Client) ListContainerGroups(resourceGroup string) (*ContainerGroupListResult, error) {
+	iter, err := c.groupsClient.ListByResourceGroupComplete(context.Background(), resourceGroup)
+	if err != nil {
+		return nil, unwrapCloudError(err)
+	}
+
+	var groups []ContainerGroup
+	for iter.NotDone() {
+		groups = append(groups, fromSDKContainerGroup(iter.Value()))
+		if err := iter.NextWithContext(context.Background()); err != nil {
+			return nil, unwrapCloudError(err)
+		}
+	}
+
+	return &ContainerGroupListResult{Value: groups}, nil
+}
+
+// DeleteContainerGroup deletes a container group and waits for the
+// delete to complete.
+func (c *Client) DeleteContainerGroup(resourceGroup, containerGroupName string) error {
+	_, err := c.groupsClient.Delete(context.Background(), resourceGroup, containerGroupName)
+	return unwrapCloudError(err)
+}
+
+// needsRawTransport reports whether cg uses a field the generated SDK
+// type can't carry, and so must go over createContainerGroupRaw
+// rather than the typed groupsClient.CreateOrUpdate.
+func needsRawTransport(cg ContainerGroup) bool {
+	if len(cg.Extensions) > 0 || len(cg.InitContainers) > 0 || cg.Sku != "" || cg.ConfidentialComputeProperties != nil {
+		return true
+	}
+	for _, container := range cg.Containers {
+		if container.Resources.Requests.Gpu != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) containerGroupURI(resourceGroup, containerGroupName string) string {
+	return fmt.Sprintf(
+		"https://management.azure.com/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerInstance/containerGroups/%s?api-version=2018-10-01",
+		c.groupsClient.SubscriptionID, resourceGroup, containerGroupName,
+	)
+}
+
+// createContainerGroupRaw PUTs a ContainerGroup body directly,
+// bypassing the generated SDK type, then polls getContainerGroupRaw
+// (via waitForTerminalProvisioningState) until the deployment reaches
+// a terminal provisioning state.
+func (c *Client) createContainerGroupRaw(resourceGroup, containerGroupName string, cg ContainerGroup) (*ContainerGroup, error) {
+	body, err := json.Marshal(cg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPut, c.containerGroupURI(resourceGroup, containerGroupName), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpReq, err = autorestPrepare(c, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.groupsClient.Client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if typed := parseCloudError(respBody); typed != nil {
+			return nil, typed
+		}
+		return nil, fmt.Errorf("aci: create container group failed with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return c.waitForTerminalProvisioningState(resourceGroup, containerGroupName)
+}
+
+// waitForTerminalProvisioningState polls the container group over a
+// raw GET (rather than the SDK-typed GetContainerGroup) until its
+// ProvisioningState is no longer empty, "Creating" or "Updating"
+// (giving up after 60 polls 5s apart), mirroring what the
+// SDK's long-running-operation future does for the typed create path.
+// The raw GET is needed so fields the SDK type doesn't model (e.g.
+// Extensions) survive the round trip.
+func (c *Client) waitForTerminalProvisioningState(resourceGroup, containerGroupName string) (*ContainerGroup, error) {
+	for i := 0; i < 60; i++ {
+		cg, err := c.getContainerGroupRaw(resourceGroup, containerGroupName)
+		if err != nil {
+			return nil, err
+		}
+
+		switch cg.ProvisioningState {
+		case "", "Creating", "Updating":
+			time.Sleep(5 * time.Second)
+			continue
+		default:
+			return cg, nil
+		}
+	}
+
+	return nil, fmt.Errorf("aci: timed out waiting for container group %q to finish provisioning", containerGroupName)
+}
+
+func (c *Client) getContainerGroupRaw(resourceGroup, containerGroupName string) (*ContainerGroup, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, c.containerGroupURI(resourceGroup, containerGroupName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err = autorestPrepare(c, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.groupsClient.Client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if typed := parseCloudError(body); typed != nil {
+			return nil, typed
+		}
+		return nil, fmt.Errorf("aci: get container group failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var cg ContainerGroup
+	if err := json.Unmarshal(body, &cg); err != nil {
+		return nil, err
+	}
+
+	return &cg, nil
+}
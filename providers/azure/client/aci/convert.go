@@ -0,0 +1,486 @@
+package aci
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+	"github.com/Azure/go-autorest/autorest/date"
+)
+
+// toSDKContainerGroup converts our ContainerGroup into the shape the
+// generated SDK client expects on the wire. It only needs to go in
+// this direction for request bodies; fromSDKContainerGroup below
+// handles responses.
+func toSDKContainerGroup(cg ContainerGroup) containerinstance.ContainerGroup {
+	return containerinstance.ContainerGroup{
+		Name:     strPtr(cg.Name),
+		Location: strPtr(cg.Location),
+		Tags:     toStrPtrMap(cg.Tags),
+		ContainerGroupProperties: &containerinstance.ContainerGroupProperties{
+			Containers:               toSDKContainers(cg.Containers),
+			OsType:                   containerinstance.OperatingSystemTypes(cg.OsType),
+			RestartPolicy:            containerinstance.ContainerGroupRestartPolicy(cg.RestartPolicy),
+			Volumes:                  toSDKVolumes(cg.Volumes),
+			ImageRegistryCredentials: toSDKImageRegistryCredentials(cg.ImageRegistryCredentials),
+			NetworkProfile:           toSDKNetworkProfile(cg.NetworkProfile),
+			IPAddress:                toSDKIPAddress(cg.IPAddress),
+		},
+	}
+}
+
+func toSDKIPAddress(ip *IPAddress) *containerinstance.IPAddress {
+	if ip == nil {
+		return nil
+	}
+	return &containerinstance.IPAddress{
+		Ports:        toSDKGroupPorts(ip.Ports),
+		IP:           strPtrOrNil(ip.IP),
+		Type:         containerinstance.ContainerGroupIPAddressType(ip.Type),
+		DNSNameLabel: strPtrOrNil(ip.DNSNameLabel),
+	}
+}
+
+func toSDKGroupPorts(ports []Port) *[]containerinstance.Port {
+	if ports == nil {
+		return nil
+	}
+	out := make([]containerinstance.Port, 0, len(ports))
+	for _, p := range ports {
+		out = append(out, containerinstance.Port{
+			Protocol: containerinstance.ContainerNetworkProtocol(p.Protocol),
+			Port:     int32Ptr(p.Port),
+		})
+	}
+	return &out
+}
+
+func toSDKNetworkProfile(np *NetworkProfileDefinition) *containerinstance.ContainerGroupNetworkProfile {
+	if np == nil {
+		return nil
+	}
+	return &containerinstance.ContainerGroupNetworkProfile{ID: strPtr(np.ID)}
+}
+
+func fromSDKNetworkProfile(np *containerinstance.ContainerGroupNetworkProfile) *NetworkProfileDefinition {
+	if np == nil {
+		return nil
+	}
+	return &NetworkProfileDefinition{ID: derefStr(np.ID)}
+}
+
+// fromSDKContainerGroup converts a containerinstance.ContainerGroup
+// response back into our own type.
+func fromSDKContainerGroup(cg containerinstance.ContainerGroup) ContainerGroup {
+	out := ContainerGroup{
+		ID:       derefStr(cg.ID),
+		Name:     derefStr(cg.Name),
+		Type:     derefStr(cg.Type),
+		Location: derefStr(cg.Location),
+		Tags:     fromStrPtrMap(cg.Tags),
+	}
+
+	if cg.ContainerGroupProperties == nil {
+		return out
+	}
+
+	out.ProvisioningState = derefStr(cg.ProvisioningState)
+	out.OsType = OsType(cg.OsType)
+	out.RestartPolicy = ContainerGroupRestartPolicy(cg.RestartPolicy)
+	out.Containers = fromSDKContainers(cg.Containers)
+	out.Volumes = fromSDKVolumes(cg.Volumes)
+
+	if cg.IPAddress != nil {
+		out.IPAddress = &IPAddress{
+			IP:           derefStr(cg.IPAddress.IP),
+			Type:         string(cg.IPAddress.Type),
+			DNSNameLabel: derefStr(cg.IPAddress.DNSNameLabel),
+			Fqdn:         derefStr(cg.IPAddress.Fqdn),
+			Ports:        fromSDKGroupPorts(cg.IPAddress.Ports),
+		}
+	}
+
+	if cg.InstanceView != nil {
+		out.InstanceView = &ContainerGroupInstanceView{
+			State:  derefStr(cg.InstanceView.State),
+			Events: fromSDKEvents(cg.InstanceView.Events),
+		}
+	}
+
+	out.NetworkProfile = fromSDKNetworkProfile(cg.NetworkProfile)
+
+	return out
+}
+
+func toSDKContainers(containers []Container) *[]containerinstance.Container {
+	if containers == nil {
+		return nil
+	}
+
+	out := make([]containerinstance.Container, 0, len(containers))
+	for _, c := range containers {
+		reqs := c.Resources.Requests
+		out = append(out, containerinstance.Container{
+			Name: strPtr(c.Name),
+			ContainerProperties: &containerinstance.ContainerProperties{
+				Image:                strPtr(c.Image),
+				Command:              toStrSlicePtr(c.Command),
+				Ports:                toSDKContainerPorts(c.Ports),
+				EnvironmentVariables: toSDKEnvVars(c.EnvironmentVariables),
+				VolumeMounts:         toSDKVolumeMounts(c.VolumeMounts),
+				Resources: &containerinstance.ResourceRequirements{
+					Requests: &containerinstance.ResourceRequests{
+						CPU:        &reqs.CPU,
+						MemoryInGB: &reqs.MemoryInGB,
+					},
+					Limits: &containerinstance.ResourceLimits{
+						CPU:        floatPtrOrNil(c.Resources.Limits.CPU),
+						MemoryInGB: floatPtrOrNil(c.Resources.Limits.MemoryInGB),
+					},
+				},
+			},
+		})
+	}
+	return &out
+}
+
+func fromSDKContainers(containers *[]containerinstance.Container) []Container {
+	if containers == nil {
+		return nil
+	}
+
+	out := make([]Container, 0, len(*containers))
+	for _, c := range *containers {
+		container := Container{Name: derefStr(c.Name)}
+		if c.ContainerProperties != nil {
+			container.Image = derefStr(c.Image)
+			container.Command = derefStrSlice(c.Command)
+			container.Ports = fromSDKContainerPorts(c.Ports)
+			container.EnvironmentVariables = fromSDKEnvVars(c.EnvironmentVariables)
+			container.VolumeMounts = fromSDKVolumeMounts(c.VolumeMounts)
+			if c.Resources != nil {
+				if c.Resources.Requests != nil {
+					container.Resources.Requests = ResourceRequests{
+						CPU:        derefFloat(c.Resources.Requests.CPU),
+						MemoryInGB: derefFloat(c.Resources.Requests.MemoryInGB),
+					}
+				}
+				if c.Resources.Limits != nil {
+					container.Resources.Limits = ResourceLimits{
+						CPU:        derefFloat(c.Resources.Limits.CPU),
+						MemoryInGB: derefFloat(c.Resources.Limits.MemoryInGB),
+					}
+				}
+			}
+			if c.InstanceView != nil {
+				container.InstanceView = &ContainerInstanceView{
+					RestartCount: derefInt32(c.InstanceView.RestartCount),
+					Events:       fromSDKEvents(c.InstanceView.Events),
+				}
+				if c.InstanceView.CurrentState != nil {
+					container.InstanceView.CurrentState = fromSDKContainerState(c.InstanceView.CurrentState)
+				}
+				if c.InstanceView.PreviousState != nil {
+					container.InstanceView.PreviousState = fromSDKContainerState(c.InstanceView.PreviousState)
+				}
+			}
+		}
+		out = append(out, container)
+	}
+	return out
+}
+
+func fromSDKContainerState(s *containerinstance.ContainerState) *ContainerState {
+	return &ContainerState{
+		State:        derefStr(s.State),
+		StartTime:    derefDateTime(s.StartTime),
+		ExitCode:     derefInt32(s.ExitCode),
+		FinishTime:   derefDateTime(s.FinishTime),
+		DetailStatus: derefStr(s.DetailStatus),
+	}
+}
+
+// derefDateTime stringifies a *date.Time, returning "" if it's nil —
+// StartTime is nil for a container that hasn't started yet, and
+// FinishTime is nil for one that hasn't finished (e.g. any Running or
+// Waiting container), so this is the common case, not an edge case.
+func derefDateTime(t *date.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
+
+func toSDKContainerPorts(ports []ContainerPort) *[]containerinstance.ContainerPort {
+	if ports == nil {
+		return nil
+	}
+	out := make([]containerinstance.ContainerPort, 0, len(ports))
+	for _, p := range ports {
+		out = append(out, containerinstance.ContainerPort{
+			Protocol: containerinstance.ContainerNetworkProtocol(p.Protocol),
+			Port:     int32Ptr(p.Port),
+		})
+	}
+	return &out
+}
+
+func fromSDKContainerPorts(ports *[]containerinstance.ContainerPort) []ContainerPort {
+	if ports == nil {
+		return nil
+	}
+	out := make([]ContainerPort, 0, len(*ports))
+	for _, p := range *ports {
+		out = append(out, ContainerPort{
+			Protocol: ContainerNetworkProtocol(p.Protocol),
+			Port:     derefInt32(p.Port),
+		})
+	}
+	return out
+}
+
+func fromSDKGroupPorts(ports *[]containerinstance.Port) []Port {
+	if ports == nil {
+		return nil
+	}
+	out := make([]Port, 0, len(*ports))
+	for _, p := range *ports {
+		out = append(out, Port{
+			Protocol: ContainerNetworkProtocol(p.Protocol),
+			Port:     derefInt32(p.Port),
+		})
+	}
+	return out
+}
+
+func toSDKEnvVars(vars []EnvironmentVariable) *[]containerinstance.EnvironmentVariable {
+	if vars == nil {
+		return nil
+	}
+	out := make([]containerinstance.EnvironmentVariable, 0, len(vars))
+	for _, v := range vars {
+		out = append(out, containerinstance.EnvironmentVariable{
+			Name:        strPtr(v.Name),
+			Value:       strPtrOrNil(v.Value),
+			SecureValue: strPtrOrNil(v.SecureValue),
+		})
+	}
+	return &out
+}
+
+func fromSDKEnvVars(vars *[]containerinstance.EnvironmentVariable) []EnvironmentVariable {
+	if vars == nil {
+		return nil
+	}
+	out := make([]EnvironmentVariable, 0, len(*vars))
+	for _, v := range *vars {
+		out = append(out, EnvironmentVariable{
+			Name:        derefStr(v.Name),
+			Value:       derefStr(v.Value),
+			SecureValue: derefStr(v.SecureValue),
+		})
+	}
+	return out
+}
+
+func toSDKVolumeMounts(mounts []VolumeMount) *[]containerinstance.VolumeMount {
+	if mounts == nil {
+		return nil
+	}
+	out := make([]containerinstance.VolumeMount, 0, len(mounts))
+	for _, m := range mounts {
+		out = append(out, containerinstance.VolumeMount{
+			Name:      strPtr(m.Name),
+			MountPath: strPtr(m.MountPath),
+			ReadOnly:  boolPtr(m.ReadOnly),
+		})
+	}
+	return &out
+}
+
+func fromSDKVolumeMounts(mounts *[]containerinstance.VolumeMount) []VolumeMount {
+	if mounts == nil {
+		return nil
+	}
+	out := make([]VolumeMount, 0, len(*mounts))
+	for _, m := range *mounts {
+		out = append(out, VolumeMount{
+			Name:      derefStr(m.Name),
+			MountPath: derefStr(m.MountPath),
+			ReadOnly:  derefBool(m.ReadOnly),
+		})
+	}
+	return out
+}
+
+func toSDKVolumes(volumes []Volume) *[]containerinstance.Volume {
+	if volumes == nil {
+		return nil
+	}
+	out := make([]containerinstance.Volume, 0, len(volumes))
+	for _, v := range volumes {
+		sdkVolume := containerinstance.Volume{Name: strPtr(v.Name)}
+		if v.AzureFile != nil {
+			sdkVolume.AzureFile = &containerinstance.AzureFileVolume{
+				ShareName:          strPtr(v.AzureFile.ShareName),
+				ReadOnly:           boolPtr(v.AzureFile.ReadOnly),
+				StorageAccountName: strPtr(v.AzureFile.StorageAccountName),
+				StorageAccountKey:  strPtrOrNil(v.AzureFile.StorageAccountKey),
+			}
+		}
+		if v.GitRepo != nil {
+			sdkVolume.GitRepo = &containerinstance.GitRepoVolume{
+				Directory:  strPtrOrNil(v.GitRepo.Directory),
+				Repository: strPtr(v.GitRepo.Repository),
+				Revision:   strPtrOrNil(v.GitRepo.Revision),
+			}
+		}
+		out = append(out, sdkVolume)
+	}
+	return &out
+}
+
+func fromSDKVolumes(volumes *[]containerinstance.Volume) []Volume {
+	if volumes == nil {
+		return nil
+	}
+	out := make([]Volume, 0, len(*volumes))
+	for _, v := range *volumes {
+		volume := Volume{Name: derefStr(v.Name)}
+		if v.AzureFile != nil {
+			volume.AzureFile = &AzureFileVolume{
+				ShareName:          derefStr(v.AzureFile.ShareName),
+				ReadOnly:           derefBool(v.AzureFile.ReadOnly),
+				StorageAccountName: derefStr(v.AzureFile.StorageAccountName),
+			}
+		}
+		if v.GitRepo != nil {
+			volume.GitRepo = &GitRepoVolume{
+				Directory:  derefStr(v.GitRepo.Directory),
+				Repository: derefStr(v.GitRepo.Repository),
+				Revision:   derefStr(v.GitRepo.Revision),
+			}
+		}
+		out = append(out, volume)
+	}
+	return out
+}
+
+func toSDKImageRegistryCredentials(creds []ImageRegistryCredential) *[]containerinstance.ImageRegistryCredential {
+	if creds == nil {
+		return nil
+	}
+	out := make([]containerinstance.ImageRegistryCredential, 0, len(creds))
+	for _, cred := range creds {
+		out = append(out, containerinstance.ImageRegistryCredential{
+			Server:   strPtr(cred.Server),
+			Username: strPtr(cred.Username),
+			Password: strPtrOrNil(cred.Password),
+		})
+	}
+	return &out
+}
+
+func fromSDKEvents(events *[]containerinstance.Event) []Event {
+	if events == nil {
+		return nil
+	}
+	out := make([]Event, 0, len(*events))
+	for _, e := range *events {
+		out = append(out, Event{
+			Count:   derefInt32(e.Count),
+			Name:    derefStr(e.Name),
+			Message: derefStr(e.Message),
+			Type:    derefStr(e.Type),
+		})
+	}
+	return out
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func strPtrOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toStrSlicePtr(s []string) *[]string {
+	if s == nil {
+		return nil
+	}
+	return &s
+}
+
+func derefStrSlice(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func toStrPtrMap(m map[string]string) map[string]*string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]*string, len(m))
+	for k, v := range m {
+		v := v
+		out[k] = &v
+	}
+	return out
+}
+
+func fromStrPtrMap(m map[string]*string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = derefStr(v)
+	}
+	return out
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func derefInt32(i *int32) int32 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func floatPtrOrNil(f float64) *float64 {
+	if f == 0 {
+		return nil
+	}
+	return &f
+}
+
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func derefBool(b *bool) bool {
+	if b == nil {
+		return false
+	}
+	return *b
+}
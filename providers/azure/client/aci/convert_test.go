@@ -0,0 +1,18 @@
+package aci
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+)
+
+func TestFromSDKContainerStateHandlesNilTimestamps(t *testing.T) {
+	running := "Running"
+	state := fromSDKContainerState(&containerinstance.ContainerState{
+		State: &running,
+	})
+
+	if state.StartTime != "" || state.FinishTime != "" {
+		t.Fatalf("expected empty timestamps for a container with no StartTime/FinishTime, got %+v", state)
+	}
+}
@@ -0,0 +1,139 @@
+package aci
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// Error codes as returned in the "code" field of an ARM CloudError
+// body. The sentinel errors below carry these codes, and Error.Is
+// matches a parsed *Error against them by code.
+const (
+	errCodeResourceRequestsNotSpecified   = "ResourceSomeRequestsNotSpecified"
+	errCodeContainerGroupNotFound         = "ContainerGroupNotFound"
+	errCodeQuotaExceeded                  = "QuotaExceeded"
+	errCodeInvalidImageRegistryCredential = "InvalidImageRegistryCredential"
+)
+
+// Error is a typed ACI error, parsed out of an ARM CloudError
+// response. Callers that only care whether a particular failure
+// occurred should use errors.Is against the sentinel values below
+// rather than comparing Code directly.
+type Error struct {
+	Code    string
+	Message string
+	Target  string
+}
+
+func (e *Error) Error() string {
+	if e.Target != "" {
+		return fmt.Sprintf("aci: %s: %s (target: %s)", e.Code, e.Message, e.Target)
+	}
+	return fmt.Sprintf("aci: %s: %s", e.Code, e.Message)
+}
+
+// Is makes the sentinel errors below usable with errors.Is: two
+// *Error values are considered equal if they share a Code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+var (
+	// ErrResourceRequestsNotSpecified is returned when a container is
+	// created without CPU/memory requests.
+	ErrResourceRequestsNotSpecified = &Error{Code: errCodeResourceRequestsNotSpecified}
+	// ErrContainerGroupNotFound is returned when the requested
+	// container group does not exist.
+	ErrContainerGroupNotFound = &Error{Code: errCodeContainerGroupNotFound}
+	// ErrQuotaExceeded is returned when creating a container group
+	// would exceed a subscription quota.
+	ErrQuotaExceeded = &Error{Code: errCodeQuotaExceeded}
+	// ErrInvalidImageRegistryCredential is returned when the image
+	// registry credentials supplied for a container are rejected.
+	ErrInvalidImageRegistryCredential = &Error{Code: errCodeInvalidImageRegistryCredential}
+)
+
+// cloudError mirrors the ARM CloudError envelope:
+// {"error": {"code": "...", "message": "...", "target": "..."}}. Used
+// by the raw-transport paths in client.go/exec.go, which parse an ARM
+// response body directly rather than going through the generated SDK
+// client.
+type cloudError struct {
+	Body struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Target  string `json:"target"`
+	} `json:"error"`
+}
+
+// parseCloudError parses an ARM error response body into an *Error
+// carrying the code, message and target from the body, whatever the
+// code is; errors.Is then matches it against the sentinels above. It
+// returns nil if the body can't be parsed or has no code.
+func parseCloudError(body []byte) *Error {
+	var parsed cloudError
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil
+	}
+
+	if parsed.Body.Code == "" {
+		return nil
+	}
+
+	return &Error{
+		Code:    parsed.Body.Code,
+		Message: parsed.Body.Message,
+		Target:  parsed.Body.Target,
+	}
+}
+
+// unwrapCloudError surfaces the ARM CloudError embedded in an
+// *azure.RequestError (which the generated containerinstance SDK
+// client returns wrapped in an autorest.DetailedError on a non-2xx
+// response) as a typed *Error. A 404 with no ServiceError becomes
+// ErrContainerGroupNotFound; any other error without a ServiceError
+// to unwrap (e.g. a network failure) is returned unchanged.
+func unwrapCloudError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var reqErr *azure.RequestError
+	switch e := err.(type) {
+	case *azure.RequestError:
+		reqErr = e
+	case autorest.DetailedError:
+		switch orig := e.Original.(type) {
+		case *azure.RequestError:
+			reqErr = orig
+		case *azure.ServiceError:
+			reqErr = &azure.RequestError{DetailedError: e, ServiceError: orig}
+		}
+	}
+	if reqErr == nil {
+		return err
+	}
+
+	if reqErr.ServiceError == nil || reqErr.ServiceError.Code == "" {
+		if reqErr.DetailedError.StatusCode == 404 {
+			return ErrContainerGroupNotFound
+		}
+		return err
+	}
+
+	typed := &Error{
+		Code:    reqErr.ServiceError.Code,
+		Message: reqErr.ServiceError.Message,
+	}
+	if reqErr.ServiceError.Target != nil {
+		typed.Target = *reqErr.ServiceError.Target
+	}
+	return typed
+}
@@ -0,0 +1,43 @@
+package aci
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestUnwrapCloudErrorMatchesKnownCode(t *testing.T) {
+	target := "properties.containers[0].properties.resources.requests"
+	reqErr := &azure.RequestError{
+		DetailedError: autorest.DetailedError{StatusCode: 400},
+		ServiceError: &azure.ServiceError{
+			Code:    "ResourceSomeRequestsNotSpecified",
+			Message: "One or more requests for this container are missing or invalid.",
+			Target:  &target,
+		},
+	}
+
+	if err := unwrapCloudError(reqErr); !errors.Is(err, ErrResourceRequestsNotSpecified) {
+		t.Fatalf("expected ErrResourceRequestsNotSpecified, got %v", err)
+	}
+}
+
+func TestUnwrapCloudErrorFallsBackTo404(t *testing.T) {
+	reqErr := &azure.RequestError{
+		DetailedError: autorest.DetailedError{StatusCode: 404},
+	}
+
+	if err := unwrapCloudError(reqErr); !errors.Is(err, ErrContainerGroupNotFound) {
+		t.Fatalf("expected ErrContainerGroupNotFound, got %v", err)
+	}
+}
+
+func TestUnwrapCloudErrorPassesThroughUnrecognizedErrors(t *testing.T) {
+	original := errors.New("connection reset by peer")
+
+	if err := unwrapCloudError(original); err != original {
+		t.Fatalf("expected the original error to pass through unchanged, got %v", err)
+	}
+}
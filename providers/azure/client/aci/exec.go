@@ -0,0 +1,284 @@
+package aci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+// ExecRequest describes a command to launch inside a running
+// container via the ACI "exec" endpoint.
+type ExecRequest struct {
+	Command      string       `json:"command"`
+	TerminalSize TerminalSize `json:"terminalSize"`
+}
+
+// TerminalSize is the initial size of the pty ACI allocates for the
+// exec session. Resizes after that go over the ResizeCh of the ExecIO
+// returned by DialExec.
+type TerminalSize struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+}
+
+// ExecResponse is the websocket connection info ACI hands back for an
+// exec session.
+type ExecResponse struct {
+	WebSocketURI string `json:"webSocketUri"`
+	Password     string `json:"password"`
+}
+
+// ExecIO is a bidirectional stream into a running exec session, plus
+// a channel to push terminal resizes down the same connection (ACI
+// multiplexes resize messages over the exec websocket rather than
+// exposing a separate endpoint).
+type ExecIO struct {
+	io.ReadWriteCloser
+	ResizeCh chan<- TerminalSize
+}
+
+// Close closes the resize channel, which stops DialExec's
+// resize-forwarding goroutine, and then closes the underlying
+// connection.
+func (e *ExecIO) Close() error {
+	close(e.ResizeCh)
+	return e.ReadWriteCloser.Close()
+}
+
+// LaunchExec starts a new exec session inside a running container and
+// returns the websocket URI and one-time password ACI hands back. It
+// does not connect to the websocket itself; pass the ExecResponse to
+// DialExec for that.
+func (c *Client) LaunchExec(resourceGroup, containerGroupName, containerName string, req ExecRequest) (ExecResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+
+	uri := fmt.Sprintf(
+		"https://management.azure.com/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerInstance/containerGroups/%s/containers/%s/exec?api-version=2018-10-01",
+		c.groupsClient.SubscriptionID, resourceGroup, containerGroupName, containerName,
+	)
+
+	httpReq, err := http.NewRequest(http.MethodPost, uri, bytes.NewReader(body))
+	if err != nil {
+		return ExecResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpReq, err = autorestPrepare(c, httpReq)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+
+	resp, err := c.groupsClient.Client.Do(httpReq)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if typed := parseCloudError(respBody); typed != nil {
+			return ExecResponse{}, typed
+		}
+		return ExecResponse{}, fmt.Errorf("aci: exec request failed with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var execResp ExecResponse
+	if err := json.Unmarshal(respBody, &execResp); err != nil {
+		return ExecResponse{}, err
+	}
+
+	return execResp, nil
+}
+
+// DialExec connects to the websocket described by an ExecResponse and
+// returns a bidirectional stream for the session's stdio, along with
+// a channel for pushing terminal resizes. The caller is responsible
+// for closing the returned ExecIO when the session ends.
+func DialExec(ctx context.Context, execResp ExecResponse) (*ExecIO, error) {
+	u, err := url.Parse(execResp.WebSocketURI)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, _, err := ws.Dial(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wsutil.WriteClientMessage(conn, ws.OpText, []byte(execResp.Password)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	resizeCh := make(chan TerminalSize)
+	go func() {
+		for size := range resizeCh {
+			payload, err := json.Marshal(size)
+			if err != nil {
+				continue
+			}
+			if err := wsutil.WriteClientMessage(conn, ws.OpText, payload); err != nil {
+				return
+			}
+		}
+	}()
+
+	return &ExecIO{ReadWriteCloser: conn, ResizeCh: resizeCh}, nil
+}
+
+// ContainerLogsOptions controls how ContainerLogsStream fetches logs.
+type ContainerLogsOptions struct {
+	Tail         int
+	SinceSeconds int
+	Follow       bool
+	PollInterval time.Duration
+}
+
+// ContainerLogsStream returns the logs for a single container in a
+// container group. Without Follow it makes a single request; with
+// Follow it polls on PollInterval (defaulting to 2s), writing only
+// the bytes not already emitted on each poll, and stops when ctx is
+// canceled.
+func (c *Client) ContainerLogsStream(ctx context.Context, resourceGroup, containerGroupName, containerName string, opts ContainerLogsOptions) (io.ReadCloser, error) {
+	if !opts.Follow {
+		logs, err := c.getContainerLogs(ctx, resourceGroup, containerGroupName, containerName, opts.Tail, opts.SinceSeconds)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader([]byte(logs))), nil
+	}
+
+	pr, pw := io.Pipe()
+	go c.followContainerLogs(ctx, pw, resourceGroup, containerGroupName, containerName, opts)
+	return pr, nil
+}
+
+func (c *Client) followContainerLogs(ctx context.Context, pw *io.PipeWriter, resourceGroup, containerGroupName, containerName string, opts ContainerLogsOptions) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// The ACI 2018-10-01 logs endpoint only honors Tail: it ignores
+	// SinceSeconds and returns the same window of logs on every poll.
+	// emitted tracks what's already been written so each poll writes
+	// only what follows the longest overlap between the end of emitted
+	// and the start of the new window, instead of re-streaming the
+	// whole window.
+	var emitted string
+	for {
+		select {
+		case <-ctx.Done():
+			pw.CloseWithError(ctx.Err())
+			return
+		case <-ticker.C:
+			logs, err := c.getContainerLogs(ctx, resourceGroup, containerGroupName, containerName, opts.Tail, opts.SinceSeconds)
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+
+			next := logs
+			for i := 0; i < len(emitted); i++ {
+				if strings.HasPrefix(logs, emitted[i:]) {
+					next = logs[len(emitted)-i:]
+					break
+				}
+			}
+			emitted = logs
+
+			if next == "" {
+				continue
+			}
+			if _, err := pw.Write([]byte(next)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// containerLogs mirrors the "logs" response ACI returns.
+type containerLogs struct {
+	Content string `json:"content"`
+}
+
+func (c *Client) getContainerLogs(ctx context.Context, resourceGroup, containerGroupName, containerName string, tail, sinceSeconds int) (string, error) {
+	uri := fmt.Sprintf(
+		"https://management.azure.com/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerInstance/containerGroups/%s/containers/%s/logs?api-version=2018-10-01",
+		c.groupsClient.SubscriptionID, resourceGroup, containerGroupName, containerName,
+	)
+
+	q := url.Values{}
+	if tail > 0 {
+		q.Set("tail", fmt.Sprintf("%d", tail))
+	}
+	if sinceSeconds > 0 {
+		q.Set("sinceSeconds", fmt.Sprintf("%d", sinceSeconds))
+	}
+	if len(q) > 0 {
+		uri += "&" + q.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err = autorestPrepare(c, httpReq)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.groupsClient.Client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if typed := parseCloudError(body); typed != nil {
+			return "", typed
+		}
+		return "", fmt.Errorf("aci: logs request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var logs containerLogs
+	if err := json.Unmarshal(body, &logs); err != nil {
+		return "", err
+	}
+
+	return logs.Content, nil
+}
+
+// autorestPrepare authorizes an *http.Request with the same
+// authorizer the groups client uses, since the exec and logs
+// endpoints aren't exposed by the generated SDK client.
+func autorestPrepare(c *Client, req *http.Request) (*http.Request, error) {
+	return autorest.CreatePreparer(c.groupsClient.Authorizer.WithAuthorization()).Prepare(req)
+}
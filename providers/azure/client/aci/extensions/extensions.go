@@ -0,0 +1,73 @@
+// Package extensions builds the Container Group Extension payloads
+// that virtual-node plumbs into ACI pods: kube-proxy (so the pod's
+// ClusterIP is reachable) and the realtime-metrics sidecar (so
+// metrics-server can scrape it). See aci.Extension for the wire type
+// these build.
+package extensions
+
+import (
+	"strconv"
+
+	"github.com/virtual-kubelet/virtual-kubelet/providers/azure/client/aci"
+)
+
+const (
+	kubeProxyExtensionType       = "kube-proxy"
+	realtimeMetricsExtensionType = "realtime-metrics"
+	extensionVersion             = "1.0"
+)
+
+// KubeProxyConfig configures the kube-proxy extension.
+type KubeProxyConfig struct {
+	ClusterCIDR               string
+	KubeConfigSecret          string
+	KubeConfigSecretNamespace string
+}
+
+// NewKubeProxyExtension builds the Extension that plumbs kube-proxy
+// into a container group, pointing it at the cluster CIDR to proxy
+// for and the kubeconfig secret it should authenticate with.
+func NewKubeProxyExtension(cfg KubeProxyConfig) aci.Extension {
+	return aci.Extension{
+		Name: kubeProxyExtensionType,
+		Properties: aci.ExtensionProperties{
+			ExtensionType: kubeProxyExtensionType,
+			Version:       extensionVersion,
+			Settings: map[string]string{
+				"clusterCidr": cfg.ClusterCIDR,
+			},
+			ProtectedSettings: map[string]string{
+				"kubeConfigSecret":          cfg.KubeConfigSecret,
+				"kubeConfigSecretNamespace": cfg.KubeConfigSecretNamespace,
+			},
+		},
+	}
+}
+
+// RealtimeMetricsConfig configures the realtime-metrics extension.
+type RealtimeMetricsConfig struct {
+	// PollIntervalSeconds is how often the sidecar scrapes container
+	// stats and republishes them for metrics-server.
+	PollIntervalSeconds int
+}
+
+// NewRealtimeMetricsExtension builds the Extension that runs the
+// realtime-metrics sidecar, which is what lets metrics-server scrape
+// CPU/memory for pods scheduled onto a virtual-kubelet node.
+func NewRealtimeMetricsExtension(cfg RealtimeMetricsConfig) aci.Extension {
+	interval := cfg.PollIntervalSeconds
+	if interval <= 0 {
+		interval = 10
+	}
+
+	return aci.Extension{
+		Name: realtimeMetricsExtensionType,
+		Properties: aci.ExtensionProperties{
+			ExtensionType: realtimeMetricsExtensionType,
+			Version:       extensionVersion,
+			Settings: map[string]string{
+				"pollIntervalSeconds": strconv.Itoa(interval),
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package aci
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtensionJSONRoundTrip(t *testing.T) {
+	want := Extension{
+		Name: "kube-proxy",
+		Properties: ExtensionProperties{
+			ExtensionType: "kube-proxy",
+			Version:       "1.0",
+			Settings: map[string]string{
+				"clusterCidr": "10.244.0.0/16",
+			},
+			ProtectedSettings: map[string]string{
+				"kubeConfigSecret": "s3cr3t",
+			},
+		},
+	}
+
+	body, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Extension
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("extension did not round-trip through JSON: want %+v, got %+v", want, got)
+	}
+}
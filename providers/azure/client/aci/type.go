@@ -0,0 +1,295 @@
+package aci
+
+// OsType is the OS family a container group runs as.
+type OsType string
+
+const (
+	// Linux containers.
+	Linux OsType = "Linux"
+	// Windows containers.
+	Windows OsType = "Windows"
+)
+
+// ContainerNetworkProtocol is the network protocol a container port is
+// exposed on.
+type ContainerNetworkProtocol string
+
+const (
+	// ContainerNetworkProtocolTCP is the TCP protocol.
+	ContainerNetworkProtocolTCP ContainerNetworkProtocol = "TCP"
+	// ContainerNetworkProtocolUDP is the UDP protocol.
+	ContainerNetworkProtocolUDP ContainerNetworkProtocol = "UDP"
+)
+
+// ContainerGroup is our representation of an ACI container group. It is
+// kept as a plain, hand-written struct (rather than a type alias over
+// the SDK's containerinstance.ContainerGroup) so that callers in this
+// repo don't need to chase SDK churn, and so we have a place to hang
+// fields the SDK doesn't model yet. conversion to/from the SDK type
+// happens in convert.go.
+type ContainerGroup struct {
+	ID       string            `json:"id,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Type     string            `json:"type,omitempty"`
+	Location string            `json:"location,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+
+	ContainerGroupProperties `json:"properties,omitempty"`
+}
+
+// ContainerGroupProperties are the properties of a container group.
+type ContainerGroupProperties struct {
+	ProvisioningState             string                         `json:"provisioningState,omitempty"`
+	Containers                    []Container                    `json:"containers,omitempty"`
+	ImageRegistryCredentials      []ImageRegistryCredential      `json:"imageRegistryCredentials,omitempty"`
+	RestartPolicy                 ContainerGroupRestartPolicy    `json:"restartPolicy,omitempty"`
+	IPAddress                     *IPAddress                     `json:"ipAddress,omitempty"`
+	OsType                        OsType                         `json:"osType,omitempty"`
+	Volumes                       []Volume                       `json:"volumes,omitempty"`
+	InstanceView                  *ContainerGroupInstanceView    `json:"instanceView,omitempty"`
+	Extensions                    []Extension                    `json:"extensions,omitempty"`
+	NetworkProfile                *NetworkProfileDefinition      `json:"networkProfile,omitempty"`
+	InitContainers                []InitContainer                `json:"initContainers,omitempty"`
+	Sku                           ContainerGroupSku              `json:"sku,omitempty"`
+	ConfidentialComputeProperties *ConfidentialComputeProperties `json:"confidentialComputeProperties,omitempty"`
+}
+
+// ContainerGroupSku selects the tier of compute a container group runs
+// on.
+type ContainerGroupSku string
+
+const (
+	// ContainerGroupSkuStandard is the default, shared-host tier.
+	ContainerGroupSkuStandard ContainerGroupSku = "Standard"
+	// ContainerGroupSkuDedicated runs the group on a dedicated host.
+	ContainerGroupSkuDedicated ContainerGroupSku = "Dedicated"
+	// ContainerGroupSkuConfidential runs the group in an SEV-SNP
+	// confidential computing enclave; requires ConfidentialComputeProperties.
+	ContainerGroupSkuConfidential ContainerGroupSku = "Confidential"
+)
+
+// ConfidentialComputeProperties configures the confidential-computing
+// enclave (CCE) policy a Confidential SKU container group runs under.
+type ConfidentialComputeProperties struct {
+	CcePolicy string `json:"ccePolicy,omitempty"`
+}
+
+// InitContainer is a container that runs to completion before the
+// container group's regular containers start. Unlike Container, it
+// has no Ports or probes: ACI doesn't expose a network identity or
+// health checks for init containers.
+type InitContainer struct {
+	Name                    string `json:"name"`
+	InitContainerProperties `json:"properties"`
+}
+
+// InitContainerProperties are the properties of an InitContainer.
+type InitContainerProperties struct {
+	Image                string                `json:"image"`
+	Command              []string              `json:"command,omitempty"`
+	EnvironmentVariables []EnvironmentVariable `json:"environmentVariables,omitempty"`
+	VolumeMounts         []VolumeMount         `json:"volumeMounts,omitempty"`
+}
+
+// NetworkProfileDefinition references the ARM network profile (see
+// package client/network) that puts a container group's containers into
+// a delegated subnet, so they get a routable in-cluster IP.
+type NetworkProfileDefinition struct {
+	ID string `json:"id"`
+}
+
+// Extension is a deployment extension attached to a container group,
+// e.g. the kube-proxy or realtime-metrics sidecars the virtual-node
+// plumbing injects. See package aci/extensions for builders that
+// produce these.
+type Extension struct {
+	Name       string              `json:"name"`
+	Properties ExtensionProperties `json:"properties"`
+}
+
+// ExtensionProperties describe which extension to run and the
+// version/settings it takes.
+type ExtensionProperties struct {
+	ExtensionType     string            `json:"extensionType"`
+	Version           string            `json:"version"`
+	Settings          map[string]string `json:"settings,omitempty"`
+	ProtectedSettings map[string]string `json:"protectedSettings,omitempty"`
+}
+
+// ContainerGroupRestartPolicy controls what ACI does when a container
+// in the group exits.
+type ContainerGroupRestartPolicy string
+
+const (
+	// ContainerGroupRestartPolicyAlways always restarts containers.
+	ContainerGroupRestartPolicyAlways ContainerGroupRestartPolicy = "Always"
+	// ContainerGroupRestartPolicyNever never restarts containers.
+	ContainerGroupRestartPolicyNever ContainerGroupRestartPolicy = "Never"
+	// ContainerGroupRestartPolicyOnFailure only restarts on failure.
+	ContainerGroupRestartPolicyOnFailure ContainerGroupRestartPolicy = "OnFailure"
+)
+
+// IPAddress describes the container group's exposed IP, if any.
+type IPAddress struct {
+	Ports        []Port `json:"ports,omitempty"`
+	IP           string `json:"ip,omitempty"`
+	Type         string `json:"type,omitempty"`
+	DNSNameLabel string `json:"dnsNameLabel,omitempty"`
+	Fqdn         string `json:"fqdn,omitempty"`
+}
+
+// Port is a port exposed on the container group's IP address.
+type Port struct {
+	Protocol ContainerNetworkProtocol `json:"protocol,omitempty"`
+	Port     int32                    `json:"port,omitempty"`
+}
+
+// ImageRegistryCredential is a set of credentials used to pull images
+// from a private registry.
+type ImageRegistryCredential struct {
+	Server   string `json:"server,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Volume is a volume that can be mounted into a container.
+type Volume struct {
+	Name      string                  `json:"name"`
+	AzureFile *AzureFileVolume        `json:"azureFile,omitempty"`
+	EmptyDir  *map[string]interface{} `json:"emptyDir,omitempty"`
+	Secret    map[string]string       `json:"secret,omitempty"`
+	GitRepo   *GitRepoVolume          `json:"gitRepo,omitempty"`
+}
+
+// AzureFileVolume mounts an Azure Files share.
+type AzureFileVolume struct {
+	ShareName          string `json:"shareName"`
+	ReadOnly           bool   `json:"readOnly,omitempty"`
+	StorageAccountName string `json:"storageAccountName"`
+	StorageAccountKey  string `json:"storageAccountKey,omitempty"`
+}
+
+// GitRepoVolume mounts a git repository.
+type GitRepoVolume struct {
+	Directory  string `json:"directory,omitempty"`
+	Repository string `json:"repository"`
+	Revision   string `json:"revision,omitempty"`
+}
+
+// ContainerGroupInstanceView is the read-only runtime state of a
+// container group.
+type ContainerGroupInstanceView struct {
+	Events []Event `json:"events,omitempty"`
+	State  string  `json:"state,omitempty"`
+}
+
+// Event is a Kubernetes-style event reported by ACI.
+type Event struct {
+	Count          int32  `json:"count,omitempty"`
+	FirstTimestamp string `json:"firstTimestamp,omitempty"`
+	LastTimestamp  string `json:"lastTimestamp,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Type           string `json:"type,omitempty"`
+}
+
+// Container is a single container within a container group.
+type Container struct {
+	Name                string `json:"name"`
+	ContainerProperties `json:"properties"`
+}
+
+// ContainerProperties describe a container's image, command, and
+// resource needs.
+type ContainerProperties struct {
+	Image                string                 `json:"image"`
+	Command              []string               `json:"command,omitempty"`
+	Ports                []ContainerPort        `json:"ports,omitempty"`
+	EnvironmentVariables []EnvironmentVariable  `json:"environmentVariables,omitempty"`
+	Resources            ResourceRequirements   `json:"resources"`
+	VolumeMounts         []VolumeMount          `json:"volumeMounts,omitempty"`
+	InstanceView         *ContainerInstanceView `json:"instanceView,omitempty"`
+}
+
+// EnvironmentVariable is an env var set on a container.
+type EnvironmentVariable struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	SecureValue string `json:"secureValue,omitempty"`
+}
+
+// VolumeMount mounts a Volume into a container's filesystem.
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// ContainerInstanceView is the read-only runtime state of a container.
+type ContainerInstanceView struct {
+	RestartCount  int32           `json:"restartCount,omitempty"`
+	CurrentState  *ContainerState `json:"currentState,omitempty"`
+	PreviousState *ContainerState `json:"previousState,omitempty"`
+	Events        []Event         `json:"events,omitempty"`
+}
+
+// ContainerState is the state of a container at a point in time.
+type ContainerState struct {
+	State        string `json:"state,omitempty"`
+	StartTime    string `json:"startTime,omitempty"`
+	ExitCode     int32  `json:"exitCode,omitempty"`
+	FinishTime   string `json:"finishTime,omitempty"`
+	DetailStatus string `json:"detailStatus,omitempty"`
+}
+
+// ContainerPort is a port exposed by a container.
+type ContainerPort struct {
+	Protocol ContainerNetworkProtocol `json:"protocol,omitempty"`
+	Port     int32                    `json:"port"`
+}
+
+// ResourceRequirements are the resource requests and limits for a
+// container.
+type ResourceRequirements struct {
+	Requests ResourceRequests `json:"requests"`
+	Limits   ResourceLimits   `json:"limits,omitempty"`
+}
+
+// ResourceRequests are the minimum resources a container needs.
+type ResourceRequests struct {
+	CPU        float64      `json:"cpu"`
+	MemoryInGB float64      `json:"memoryInGB"`
+	Gpu        *GpuResource `json:"gpu,omitempty"`
+}
+
+// GpuResource requests a number of GPUs of a given SKU. Only
+// supported on Linux container groups.
+type GpuResource struct {
+	Count int32  `json:"count"`
+	Sku   GpuSku `json:"sku"`
+}
+
+// GpuSku is the GPU hardware generation to request.
+type GpuSku string
+
+const (
+	// GpuSkuK80 requests a Tesla K80.
+	GpuSkuK80 GpuSku = "K80"
+	// GpuSkuP100 requests a Tesla P100.
+	GpuSkuP100 GpuSku = "P100"
+	// GpuSkuV100 requests a Tesla V100.
+	GpuSkuV100 GpuSku = "V100"
+)
+
+// ResourceLimits cap the resources a container may use.
+type ResourceLimits struct {
+	CPU        float64 `json:"cpu,omitempty"`
+	MemoryInGB float64 `json:"memoryInGB,omitempty"`
+}
+
+// ContainerGroupListResult is the response of a list container groups
+// call.
+type ContainerGroupListResult struct {
+	Value    []ContainerGroup `json:"value,omitempty"`
+	NextLink string           `json:"nextLink,omitempty"`
+}
@@ -0,0 +1,83 @@
+package aci
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestInitContainerJSONRoundTrip(t *testing.T) {
+	want := InitContainer{
+		Name: "init-config",
+		InitContainerProperties: InitContainerProperties{
+			Image:   "busybox",
+			Command: []string{"sh", "-c", "cp /src/* /dst"},
+			VolumeMounts: []VolumeMount{
+				{Name: "config", MountPath: "/dst"},
+			},
+		},
+	}
+
+	body, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got InitContainer
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("init container did not round-trip through JSON: want %+v, got %+v", want, got)
+	}
+}
+
+func TestGpuResourceJSONRoundTrip(t *testing.T) {
+	want := ResourceRequests{
+		CPU:        4,
+		MemoryInGB: 16,
+		Gpu: &GpuResource{
+			Count: 1,
+			Sku:   GpuSkuV100,
+		},
+	}
+
+	body, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got ResourceRequests
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("resource requests did not round-trip through JSON: want %+v, got %+v", want, got)
+	}
+}
+
+func TestConfidentialComputePropertiesJSONRoundTrip(t *testing.T) {
+	want := ContainerGroupProperties{
+		OsType: Linux,
+		Sku:    ContainerGroupSkuConfidential,
+		ConfidentialComputeProperties: &ConfidentialComputeProperties{
+			CcePolicy: "eyJhbGxvd19hbGwiOnRydWV9",
+		},
+	}
+
+	body, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got ContainerGroupProperties
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("container group properties did not round-trip through JSON: want %+v, got %+v", want, got)
+	}
+}
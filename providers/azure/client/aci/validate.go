@@ -0,0 +1,52 @@
+package aci
+
+import "fmt"
+
+// ValidateContainerGroup rejects ContainerGroup values ARM is known to
+// reject, returning the first problem found. CreateContainerGroup
+// calls this before issuing the ARM request so these fail fast with a
+// clear error instead of a round trip to ARM; it's exported (and
+// re-exported from aci/validation) for callers that want to validate
+// ahead of time too.
+//
+// Init containers with ports aren't checked here: InitContainer has
+// no Ports field at all, so that case is unrepresentable rather than
+// merely unvalidated.
+func ValidateContainerGroup(cg ContainerGroup) error {
+	if err := validateGpu(cg); err != nil {
+		return err
+	}
+	if err := validateConfidentialCompute(cg); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateGpu rejects GPU requests on anything but Linux: ACI doesn't
+// offer GPU SKUs for Windows container groups.
+func validateGpu(cg ContainerGroup) error {
+	if cg.OsType == Linux {
+		return nil
+	}
+
+	for _, c := range cg.Containers {
+		if c.Resources.Requests.Gpu != nil {
+			return fmt.Errorf("aci: container %q requests a GPU, but GPUs are only available on Linux container groups (OsType is %q)", c.Name, cg.OsType)
+		}
+	}
+	return nil
+}
+
+// validateConfidentialCompute rejects the Confidential SKU unless a
+// CCE policy is set: without one ARM has nothing to attest the
+// enclave against.
+func validateConfidentialCompute(cg ContainerGroup) error {
+	if cg.Sku != ContainerGroupSkuConfidential {
+		return nil
+	}
+
+	if cg.ConfidentialComputeProperties == nil || cg.ConfidentialComputeProperties.CcePolicy == "" {
+		return fmt.Errorf("aci: container group sku is %q but no CCE policy was set", cg.Sku)
+	}
+	return nil
+}
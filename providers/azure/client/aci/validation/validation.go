@@ -0,0 +1,15 @@
+// Package validation re-exports aci.ValidateContainerGroup for
+// callers that only need validation and don't want to pull in the
+// rest of the aci client's surface. Client.CreateContainerGroup calls
+// aci.ValidateContainerGroup directly (a separate package importing
+// back into aci would cycle), so this is a thin wrapper rather than a
+// second implementation.
+package validation
+
+import "github.com/virtual-kubelet/virtual-kubelet/providers/azure/client/aci"
+
+// ValidateContainerGroup rejects ContainerGroup values ARM is known to
+// reject, returning the first problem found.
+func ValidateContainerGroup(cg aci.ContainerGroup) error {
+	return aci.ValidateContainerGroup(cg)
+}
@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/virtual-kubelet/virtual-kubelet/providers/azure/client/aci"
+)
+
+func TestValidateContainerGroupRejectsGpuOnWindows(t *testing.T) {
+	cg := aci.ContainerGroup{
+		ContainerGroupProperties: aci.ContainerGroupProperties{
+			OsType: aci.Windows,
+			Containers: []aci.Container{
+				{
+					Name: "app",
+					ContainerProperties: aci.ContainerProperties{
+						Resources: aci.ResourceRequirements{
+							Requests: aci.ResourceRequests{
+								Gpu: &aci.GpuResource{Count: 1, Sku: aci.GpuSkuK80},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := ValidateContainerGroup(cg); err == nil {
+		t.Fatal("expected an error for a GPU request on a Windows container group, got nil")
+	}
+}
+
+func TestValidateContainerGroupRejectsConfidentialWithoutCcePolicy(t *testing.T) {
+	cg := aci.ContainerGroup{
+		ContainerGroupProperties: aci.ContainerGroupProperties{
+			OsType: aci.Linux,
+			Sku:    aci.ContainerGroupSkuConfidential,
+		},
+	}
+
+	if err := ValidateContainerGroup(cg); err == nil {
+		t.Fatal("expected an error for a Confidential sku without a CCE policy, got nil")
+	}
+}
+
+func TestValidateContainerGroupAcceptsValidConfidentialGroup(t *testing.T) {
+	cg := aci.ContainerGroup{
+		ContainerGroupProperties: aci.ContainerGroupProperties{
+			OsType: aci.Linux,
+			Sku:    aci.ContainerGroupSkuConfidential,
+			ConfidentialComputeProperties: &aci.ConfidentialComputeProperties{
+				CcePolicy: "eyJhbGxvd19hbGwiOnRydWV9",
+			},
+		},
+	}
+
+	if err := ValidateContainerGroup(cg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Package network ensures the VNet, delegated subnet, and ACI network
+// profile that container groups need in order to land on a routable,
+// in-cluster IP address. It is deliberately narrow: it only knows how
+// to get these three resources into existence, not general-purpose
+// VNet management.
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+)
+
+// containerInstanceDelegation is the service delegation a subnet
+// needs before ACI will deploy container groups into it.
+const containerInstanceDelegation = "Microsoft.ContainerInstance/containerGroups"
+
+// Client wraps the generated VNet, subnet, and network-profile SDK
+// clients with the subscription ID and authorizer resolved once at
+// construction time.
+type Client struct {
+	vnetClient    network.VirtualNetworksClient
+	subnetClient  network.SubnetsClient
+	profileClient network.ProfilesClient
+}
+
+// NewClient creates a new network.Client, authorizing the same way
+// aci.NewClient and resourcegroups.NewClient do: see resolveAuthorizer.
+func NewClient() (*Client, error) {
+	authorizer, subscriptionID, err := resolveAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	vnetClient := network.NewVirtualNetworksClient(subscriptionID)
+	vnetClient.Authorizer = authorizer
+
+	subnetClient := network.NewSubnetsClient(subscriptionID)
+	subnetClient.Authorizer = authorizer
+
+	profileClient := network.NewProfilesClient(subscriptionID)
+	profileClient.Authorizer = authorizer
+
+	return &Client{
+		vnetClient:    vnetClient,
+		subnetClient:  subnetClient,
+		profileClient: profileClient,
+	}, nil
+}
+
+// resolveAuthorizer resolves an ARM authorizer and subscription ID,
+// preferring the credentials file pointed to by AZURE_AUTH_LOCATION
+// (service principal auth) and otherwise falling back to
+// auth.NewAuthorizerFromEnvironment (environment-variable credentials
+// or MSI) and then auth.NewAuthorizerFromCLI (an `az login` session),
+// so virtual-kubelet can run in-cluster or from a developer machine
+// without a credentials file. AZURE_SUBSCRIPTION_ID must be set for
+// either fallback, since neither one carries a subscription ID.
+func resolveAuthorizer() (autorest.Authorizer, string, error) {
+	if os.Getenv("AZURE_AUTH_LOCATION") != "" {
+		settings, err := auth.GetSettingsFromFile()
+		if err != nil {
+			return nil, "", err
+		}
+
+		authorizer, err := settings.ClientCredentialsAuthorizer(settings.Environment.ResourceManagerEndpoint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return authorizer, settings.GetSubscriptionID(), nil
+	}
+
+	subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+	if subscriptionID == "" {
+		return nil, "", errors.New("network: set AZURE_AUTH_LOCATION, or AZURE_SUBSCRIPTION_ID plus environment/MSI/CLI credentials")
+	}
+
+	if authorizer, err := auth.NewAuthorizerFromEnvironment(); err == nil {
+		return authorizer, subscriptionID, nil
+	}
+
+	authorizer, err := auth.NewAuthorizerFromCLI()
+	if err != nil {
+		return nil, "", err
+	}
+	return authorizer, subscriptionID, nil
+}
+
+// EnsureNetworkProfileConfig describes the VNet, subnet, and address
+// space EnsureNetworkProfile should make sure exist.
+type EnsureNetworkProfileConfig struct {
+	ResourceGroup string
+	Location      string
+	VNetName      string
+	VNetCIDR      string
+	SubnetName    string
+	SubnetCIDR    string
+}
+
+// EnsureNetworkProfile makes sure a VNet, a subnet delegated to ACI
+// within it, and a network profile referencing that subnet all exist,
+// creating whichever of the three are missing. It returns the network
+// profile's resource ID, ready to drop into
+// aci.NetworkProfileDefinition.ID.
+func (c *Client) EnsureNetworkProfile(ctx context.Context, cfg EnsureNetworkProfileConfig) (string, error) {
+	if err := c.ensureVNet(ctx, cfg); err != nil {
+		return "", fmt.Errorf("ensuring vnet %q: %w", cfg.VNetName, err)
+	}
+
+	subnet, err := c.ensureDelegatedSubnet(ctx, cfg)
+	if err != nil {
+		return "", fmt.Errorf("ensuring subnet %q: %w", cfg.SubnetName, err)
+	}
+
+	profileID, err := c.ensureNetworkProfile(ctx, cfg, subnet)
+	if err != nil {
+		return "", fmt.Errorf("ensuring network profile: %w", err)
+	}
+
+	return profileID, nil
+}
+
+func (c *Client) ensureVNet(ctx context.Context, cfg EnsureNetworkProfileConfig) error {
+	if _, err := c.vnetClient.Get(ctx, cfg.ResourceGroup, cfg.VNetName, ""); err == nil {
+		return nil
+	}
+
+	future, err := c.vnetClient.CreateOrUpdate(ctx, cfg.ResourceGroup, cfg.VNetName, network.VirtualNetwork{
+		Location: &cfg.Location,
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{
+				AddressPrefixes: &[]string{cfg.VNetCIDR},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.vnetClient.Client)
+}
+
+func (c *Client) ensureDelegatedSubnet(ctx context.Context, cfg EnsureNetworkProfileConfig) (network.Subnet, error) {
+	if subnet, err := c.subnetClient.Get(ctx, cfg.ResourceGroup, cfg.VNetName, cfg.SubnetName, ""); err == nil {
+		return subnet, nil
+	}
+
+	delegationName := "aci-delegation"
+	future, err := c.subnetClient.CreateOrUpdate(ctx, cfg.ResourceGroup, cfg.VNetName, cfg.SubnetName, network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			AddressPrefix: &cfg.SubnetCIDR,
+			Delegations: &[]network.Delegation{
+				{
+					Name: &delegationName,
+					ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+						ServiceName: stringPtr(containerInstanceDelegation),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return network.Subnet{}, err
+	}
+
+	if err := future.WaitForCompletionRef(ctx, c.subnetClient.Client); err != nil {
+		return network.Subnet{}, err
+	}
+
+	return future.Result(c.subnetClient)
+}
+
+func (c *Client) ensureNetworkProfile(ctx context.Context, cfg EnsureNetworkProfileConfig, subnet network.Subnet) (string, error) {
+	profileName := cfg.VNetName + "-" + cfg.SubnetName + "-profile"
+
+	if profile, err := c.profileClient.Get(ctx, cfg.ResourceGroup, profileName, ""); err == nil {
+		return *profile.ID, nil
+	}
+
+	profile, err := c.profileClient.CreateOrUpdate(ctx, cfg.ResourceGroup, profileName, network.Profile{
+		Location: &cfg.Location,
+		ProfilePropertiesFormat: &network.ProfilePropertiesFormat{
+			ContainerNetworkInterfaceConfigurations: &[]network.ContainerNetworkInterfaceConfiguration{
+				{
+					Name: stringPtr("eth0"),
+					ContainerNetworkInterfaceConfigurationPropertiesFormat: &network.ContainerNetworkInterfaceConfigurationPropertiesFormat{
+						IPConfigurations: &[]network.IPConfigurationProfile{
+							{
+								Name: stringPtr("ipconfig1"),
+								IPConfigurationProfilePropertiesFormat: &network.IPConfigurationProfilePropertiesFormat{
+									Subnet: subnet.ID,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return *profile.ID, nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,133 @@
+// Package resourcegroups is a thin wrapper around the Azure Resource
+// Manager "resources" SDK, scoped to the handful of resource group
+// operations the virtual-kubelet ACI provider (and its tests) need.
+package resourcegroups
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+)
+
+// Group mirrors the subset of resources.Group fields callers in this
+// repo populate or inspect. It keeps the exported surface stable even
+// as the underlying SDK type gains fields across API versions.
+type Group struct {
+	Name     string
+	Location string
+	Tags     map[string]string
+}
+
+// Client wraps the generated resources.GroupsClient with the
+// subscription ID and authorizer resolved once at construction time.
+type Client struct {
+	groupsClient resources.GroupsClient
+}
+
+// NewClient creates a new resourcegroups.Client, authorizing against
+// Azure Resource Manager the same way aci.NewClient and
+// network.NewClient do: see resolveAuthorizer.
+func NewClient() (*Client, error) {
+	authorizer, subscriptionID, err := resolveAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	groupsClient := resources.NewGroupsClient(subscriptionID)
+	groupsClient.Authorizer = authorizer
+
+	return &Client{groupsClient: groupsClient}, nil
+}
+
+// resolveAuthorizer resolves an ARM authorizer and subscription ID,
+// preferring the credentials file pointed to by AZURE_AUTH_LOCATION
+// (service principal auth) and otherwise falling back to
+// auth.NewAuthorizerFromEnvironment (environment-variable credentials
+// or MSI) and then auth.NewAuthorizerFromCLI (an `az login` session),
+// so virtual-kubelet can run in-cluster or from a developer machine
+// without a credentials file. AZURE_SUBSCRIPTION_ID must be set for
+// either fallback, since neither one carries a subscription ID.
+func resolveAuthorizer() (autorest.Authorizer, string, error) {
+	if os.Getenv("AZURE_AUTH_LOCATION") != "" {
+		settings, err := auth.GetSettingsFromFile()
+		if err != nil {
+			return nil, "", err
+		}
+
+		authorizer, err := settings.ClientCredentialsAuthorizer(settings.Environment.ResourceManagerEndpoint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return authorizer, settings.GetSubscriptionID(), nil
+	}
+
+	subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+	if subscriptionID == "" {
+		return nil, "", errors.New("resourcegroups: set AZURE_AUTH_LOCATION, or AZURE_SUBSCRIPTION_ID plus environment/MSI/CLI credentials")
+	}
+
+	if authorizer, err := auth.NewAuthorizerFromEnvironment(); err == nil {
+		return authorizer, subscriptionID, nil
+	}
+
+	authorizer, err := auth.NewAuthorizerFromCLI()
+	if err != nil {
+		return nil, "", err
+	}
+	return authorizer, subscriptionID, nil
+}
+
+// ResourceGroupExists reports whether a resource group with the given
+// name exists in the configured subscription.
+func (c *Client) ResourceGroupExists(name string) (bool, error) {
+	result, err := c.groupsClient.CheckExistence(context.Background(), name)
+	if err != nil {
+		return false, err
+	}
+	return result.StatusCode >= 200 && result.StatusCode < 300, nil
+}
+
+// CreateResourceGroup creates (or updates) the named resource group.
+func (c *Client) CreateResourceGroup(name string, group Group) (*Group, error) {
+	tags := make(map[string]*string, len(group.Tags))
+	for k, v := range group.Tags {
+		v := v
+		tags[k] = &v
+	}
+
+	result, err := c.groupsClient.CreateOrUpdate(context.Background(), name, resources.Group{
+		Location: &group.Location,
+		Tags:     tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Group{
+		Name:     derefString(result.Name),
+		Location: derefString(result.Location),
+	}, nil
+}
+
+// DeleteResourceGroup deletes the named resource group and waits for
+// the long-running delete operation to finish.
+func (c *Client) DeleteResourceGroup(name string) error {
+	future, err := c.groupsClient.Delete(context.Background(), name)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(context.Background(), c.groupsClient.Client)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}